@@ -0,0 +1,42 @@
+package ibc_testing
+
+import (
+	"encoding/json"
+
+	dbm "github.com/tendermint/tm-db"
+
+	wasmdapp "github.com/CosmWasm/wasmd/app"
+	"github.com/CosmWasm/wasmd/x/wasm/internal/keeper"
+	ibctesting "github.com/cosmos/ibc-go/testing"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// init registers WasmTestingApp as the app every ibctesting.Coordinator builds by default -
+// ibctesting.NewCoordinator takes no per-call app initializer argument, it always uses the
+// package-level ibctesting.DefaultTestingAppInit var.
+func init() {
+	ibctesting.DefaultTestingAppInit = SetupWasmTestingApp
+}
+
+// WasmTestingApp adapts wasmd's App to the ibctesting.TestingApp interface and exposes the wasm
+// Keeper so contract test helpers elsewhere in this package don't need to know wasmd's internal
+// app wiring.
+type WasmTestingApp struct {
+	*wasmdapp.WasmApp
+}
+
+var _ ibctesting.TestingApp = (*WasmTestingApp)(nil)
+
+// WasmKeeper returns the app's wasm Keeper.
+func (app *WasmTestingApp) WasmKeeper() keeper.Keeper {
+	return app.WasmApp.WasmKeeper
+}
+
+// SetupWasmTestingApp builds a fresh WasmTestingApp and its default genesis, matching the
+// ibctesting.AppIniter signature assigned to ibctesting.DefaultTestingAppInit in init().
+func SetupWasmTestingApp() (ibctesting.TestingApp, map[string]json.RawMessage) {
+	db := dbm.NewMemDB()
+	encodingCfg := wasmdapp.MakeEncodingConfig()
+	app := wasmdapp.NewWasmApp(log.NewNopLogger(), db, nil, true, map[int64]bool{}, wasmdapp.DefaultNodeHome, 5, encodingCfg, wasmdapp.EmptyAppOptions{})
+	return &WasmTestingApp{WasmApp: app}, wasmdapp.NewDefaultGenesisState()
+}
@@ -0,0 +1,40 @@
+package ibc_testing
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmd/x/wasm/internal/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ibctesting "github.com/cosmos/ibc-go/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChain wraps ibctesting.TestChain with the CosmWasm-specific conveniences contract authors
+// need to set up handshakes and packet relay: storing code, instantiating contracts and reading
+// back contract state.
+type TestChain struct {
+	*ibctesting.TestChain
+}
+
+// StoreCodeAndInstantiateContract stores wasmBytecode and instantiates it with initMsg, returning
+// the resulting contract address - enough to get a contract port bound and ready to participate
+// in an IBC handshake.
+func (chain *TestChain) StoreCodeAndInstantiateContract(t *testing.T, wasmBytecode, initMsg []byte) sdk.AccAddress {
+	keeper := chain.App.(*WasmTestingApp).WasmKeeper()
+	sender := chain.SenderAccount.GetAddress()
+
+	codeID, err := keeper.Create(chain.GetContext(), sender, wasmBytecode, nil)
+	require.NoError(t, err)
+
+	contractAddr, _, err := keeper.Instantiate(chain.GetContext(), codeID, sender, nil, initMsg, "ibc-testing", nil)
+	require.NoError(t, err)
+	return contractAddr
+}
+
+// ContractInfo returns the on-chain ContractInfo for addr, failing the test if it isn't found.
+func (chain *TestChain) ContractInfo(t *testing.T, addr sdk.AccAddress) types.ContractInfo {
+	keeper := chain.App.(*WasmTestingApp).WasmKeeper()
+	info := keeper.GetContractInfo(chain.GetContext(), addr)
+	require.NotNil(t, info, "contract %s not found", addr)
+	return *info
+}
@@ -0,0 +1,87 @@
+package ibc_testing
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/CosmWasm/wasmd/x/wasm/internal/types"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	ibctesting "github.com/cosmos/ibc-go/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// ibcReflectWasmPath is a compiled IBC-enabled contract binary, used to drive a real channel
+// handshake and packet relay end-to-end through Keeper.OnOpenChannel, Keeper.OnConnectChannel,
+// Keeper.OnRecvPacket and Keeper.OnAckPacket. It isn't part of this source snapshot - there's no
+// wasm toolchain available in this checkout to produce one - so tests that need it skip instead of
+// failing until it's added.
+const ibcReflectWasmPath = "testdata/ibc_reflect.wasm"
+
+func requireIBCReflectWasm(t *testing.T) []byte {
+	t.Helper()
+	bz, err := ioutil.ReadFile(ibcReflectWasmPath)
+	if err != nil {
+		t.Skipf("skipping: %s not available in this checkout", ibcReflectWasmPath)
+	}
+	return bz
+}
+
+// TestIBCPacketLifecycle exercises the full happy-path channel handshake and packet relay between
+// two WasmTestingApp chains for a contract-to-contract channel, covering Keeper.OnOpenChannel,
+// Keeper.OnConnectChannel, Keeper.OnRecvPacket and Keeper.OnAckPacket as ibc-go would actually
+// drive them rather than calling the keeper methods directly.
+func TestIBCPacketLifecycle(t *testing.T) {
+	wasmBytecode := requireIBCReflectWasm(t)
+
+	coord := NewCoordinator(t, 2)
+	chainA := coord.GetChain(ibctesting.GetChainID(0))
+	chainB := coord.GetChain(ibctesting.GetChainID(1))
+
+	contractA := chainA.StoreCodeAndInstantiateContract(t, wasmBytecode, []byte(`{}`))
+	contractB := chainB.StoreCodeAndInstantiateContract(t, wasmBytecode, []byte(`{}`))
+
+	path := NewContractPath(chainA, chainB, types.PortIDForContract(contractA), types.PortIDForContract(contractB), channeltypes.UNORDERED, "ibc-reflect-v1")
+	coord.SetupContractChannel(path)
+
+	packet := channeltypes.NewPacket(
+		[]byte(`{"ping":{}}`),
+		1,
+		path.EndpointA.ChannelConfig.PortID,
+		path.EndpointA.ChannelID,
+		path.EndpointB.ChannelConfig.PortID,
+		path.EndpointB.ChannelID,
+		clienttypes.ZeroHeight(),
+		uint64(chainB.GetContext().BlockTime().Add(time.Hour).UnixNano()),
+	)
+	require.NoError(t, coord.RelayPacket(path, packet))
+}
+
+// TestTimeoutPacket exercises Keeper.OnTimeoutPacket by relaying a packet whose timeout has
+// already elapsed by the time Coordinator.TimeoutPacket advances the chains' clocks.
+func TestTimeoutPacket(t *testing.T) {
+	wasmBytecode := requireIBCReflectWasm(t)
+
+	coord := NewCoordinator(t, 2)
+	chainA := coord.GetChain(ibctesting.GetChainID(0))
+	chainB := coord.GetChain(ibctesting.GetChainID(1))
+
+	contractA := chainA.StoreCodeAndInstantiateContract(t, wasmBytecode, []byte(`{}`))
+	contractB := chainB.StoreCodeAndInstantiateContract(t, wasmBytecode, []byte(`{}`))
+
+	path := NewContractPath(chainA, chainB, types.PortIDForContract(contractA), types.PortIDForContract(contractB), channeltypes.UNORDERED, "ibc-reflect-v1")
+	coord.SetupContractChannel(path)
+
+	packet := channeltypes.NewPacket(
+		[]byte(`{"ping":{}}`),
+		1,
+		path.EndpointA.ChannelConfig.PortID,
+		path.EndpointA.ChannelID,
+		path.EndpointB.ChannelConfig.PortID,
+		path.EndpointB.ChannelID,
+		clienttypes.ZeroHeight(),
+		uint64(chainA.GetContext().BlockTime().Add(time.Second).UnixNano()),
+	)
+	require.NoError(t, coord.TimeoutPacket(path, packet))
+}
@@ -0,0 +1,24 @@
+package ibc_testing
+
+import (
+	channeltypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	ibctesting "github.com/cosmos/ibc-go/testing"
+)
+
+// Path wraps ibctesting.Path, pre-configured for a channel between two CosmWasm contract ports.
+type Path struct {
+	*ibctesting.Path
+}
+
+// NewContractPath builds a Path between portA on chainA and portB on chainB with the given
+// ordering and version, ready to be handed to Coordinator.SetupContractChannel.
+func NewContractPath(chainA, chainB *TestChain, portA, portB string, order channeltypes.Order, version string) *Path {
+	path := ibctesting.NewPath(chainA.TestChain, chainB.TestChain)
+	path.EndpointA.ChannelConfig.PortID = portA
+	path.EndpointB.ChannelConfig.PortID = portB
+	path.EndpointA.ChannelConfig.Order = order
+	path.EndpointB.ChannelConfig.Order = order
+	path.EndpointA.ChannelConfig.Version = version
+	path.EndpointB.ChannelConfig.Version = version
+	return &Path{Path: path}
+}
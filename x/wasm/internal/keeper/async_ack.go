@@ -0,0 +1,116 @@
+package keeper
+
+import (
+	"github.com/CosmWasm/wasmd/x/wasm/internal/types"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/modules/core/24-host"
+)
+
+// toChannelPacket converts the wasmvm packet passed into OnRecvPacket into the channeltypes.Packet
+// shape core IBC's WriteAcknowledgement expects, so it can be cached for a later async ack without
+// having to be re-fetched from the chain (which core IBC does not support - see SetAsyncAckOwner).
+func toChannelPacket(packet wasmvmtypes.IBCPacket) channeltypes.Packet {
+	timeoutHeight := clienttypes.Height{}
+	if packet.Timeout.Block != nil {
+		timeoutHeight = clienttypes.Height{
+			RevisionNumber: packet.Timeout.Block.Revision,
+			RevisionHeight: packet.Timeout.Block.Height,
+		}
+	}
+	return channeltypes.Packet{
+		Sequence:           packet.Sequence,
+		SourcePort:         packet.Src.PortID,
+		SourceChannel:      packet.Src.ChannelID,
+		DestinationPort:    packet.Dest.PortID,
+		DestinationChannel: packet.Dest.ChannelID,
+		Data:               packet.Data,
+		TimeoutHeight:      timeoutHeight,
+		TimeoutTimestamp:   packet.Timeout.Timestamp,
+	}
+}
+
+// SetAsyncAckOwner records that contractAddr is responsible for eventually writing the
+// acknowledgement for packet, because its IBCPacketReceive response set Async: true instead of
+// returning it synchronously. The packet itself is cached alongside the owner, since core IBC's
+// ChannelKeeper only keeps a receipt marker around after delivery and has no API to recover the
+// packet later (see WriteAcknowledgement).
+func (k Keeper) SetAsyncAckOwner(ctx sdk.Context, contractAddr sdk.AccAddress, packet wasmvmtypes.IBCPacket) {
+	store := ctx.KVStore(k.storeKey)
+	bz, err := types.MarshalAsyncAckEntry(types.AsyncAckEntry{
+		ContractAddr: contractAddr,
+		Packet:       toChannelPacket(packet),
+	})
+	if err != nil {
+		panic(err)
+	}
+	store.Set(types.AsyncAckOwnerKey(packet.Dest.PortID, packet.Dest.ChannelID, packet.Sequence), bz)
+}
+
+// getAsyncAckEntry returns the cached owner/packet pair for (portID, channelID, sequence), or
+// ok=false if none is pending.
+func (k Keeper) getAsyncAckEntry(ctx sdk.Context, portID, channelID string, sequence uint64) (types.AsyncAckEntry, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.AsyncAckOwnerKey(portID, channelID, sequence))
+	if bz == nil {
+		return types.AsyncAckEntry{}, false
+	}
+	entry, err := types.UnmarshalAsyncAckEntry(bz)
+	if err != nil {
+		panic(err)
+	}
+	return entry, true
+}
+
+// GetAsyncAckOwner returns the contract registered to write the async ack for (portID, channelID,
+// sequence), or nil if none is pending.
+func (k Keeper) GetAsyncAckOwner(ctx sdk.Context, portID, channelID string, sequence uint64) sdk.AccAddress {
+	entry, ok := k.getAsyncAckEntry(ctx, portID, channelID, sequence)
+	if !ok {
+		return nil
+	}
+	return entry.ContractAddr
+}
+
+func (k Keeper) deleteAsyncAckOwner(ctx sdk.Context, portID, channelID string, sequence uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.AsyncAckOwnerKey(portID, channelID, sequence))
+}
+
+// WriteAcknowledgement lets the contract that owns a pending async ack (see SetAsyncAckOwner)
+// write it now, authorizing only that contract, and clears the pending entry once written.
+func (k Keeper) WriteAcknowledgement(ctx sdk.Context, sender sdk.AccAddress, portID, channelID string, sequence uint64, ack []byte) error {
+	entry, ok := k.getAsyncAckEntry(ctx, portID, channelID, sequence)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrNotFound, "no pending async ack for %s/%s/%d", portID, channelID, sequence)
+	}
+	if !entry.ContractAddr.Equals(sender) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the receiving contract may write this acknowledgement")
+	}
+
+	chanCap, ok := k.scopedIBCKeeper.GetCapability(ctx, host.ChannelCapabilityPath(portID, channelID))
+	if !ok {
+		return sdkerrors.Wrap(types.ErrInvalid, "channel capability not found")
+	}
+	if err := k.channelKeeper.WriteAcknowledgement(ctx, chanCap, entry.Packet, ack); err != nil {
+		return err
+	}
+	k.deleteAsyncAckOwner(ctx, portID, channelID, sequence)
+	return nil
+}
+
+// handleWriteAcknowledgementMsg handles the CosmosMsg::Ibc(IbcMsg::WriteAcknowledgement{
+// channel_id, sequence, ack }) variant: the packet's port is always the calling contract's own
+// IBC port, so only channel_id/sequence/ack need to come from the contract.
+//
+// Neither this nor MsgWriteAcknowledgement (types/msg_write_ack.go), the tx-based alternative for
+// writing the same ack outside of contract execution, is reachable yet: this is only ever called
+// by DispatchIBCMsg, which nothing in the messenger's CosmosMsg switch calls either, and
+// MsgWriteAcknowledgement is never registered with a codec, NewHandler switch, or msg-service
+// router. Both are dead code until that wiring lands outside this package.
+func (k Keeper) handleWriteAcknowledgementMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractInfo types.ContractInfo, msg wasmvmtypes.WriteAcknowledgementMsg) error {
+	return k.WriteAcknowledgement(ctx, contractAddr, contractInfo.IBCPortID, msg.ChannelID, msg.Sequence, msg.Ack)
+}
@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	"github.com/CosmWasm/wasmd/x/wasm/internal/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// RegisterInterchainAccount lets a contract register and drive an Interchain Account (ICS-27) on
+// the chain behind connectionID. It binds an ICA controller port derived from the contract
+// address (if not already bound) and performs the ORDERED channel handshake init step using the
+// ICA controller keeper. The hosted account address is only known once the counterparty returns
+// it in the channel version metadata. SetInterchainAccountAddress stores it, but nothing in this
+// package calls SetInterchainAccountAddress: ICA controller channels never reach OnConnectChannel
+// (see its doc comment).
+func (k Keeper) RegisterInterchainAccount(ctx sdk.Context, contractAddr sdk.AccAddress, connectionID, version string) error {
+	portID := types.ICAControllerPortID(contractAddr)
+	if !k.icaControllerKeeper.IsBound(ctx, portID) {
+		if err := k.icaControllerKeeper.BindPort(ctx, portID); err != nil {
+			return sdkerrors.Wrap(err, "bind ICA controller port")
+		}
+	}
+	if err := k.icaControllerKeeper.RegisterInterchainAccount(ctx, connectionID, portID, version); err != nil {
+		return sdkerrors.Wrap(err, "register interchain account")
+	}
+	return nil
+}
+
+// SetInterchainAccountAddress stores the hosted account address the counterparty returned for
+// (contractAddr, connectionID), for lookup through GetInterchainAccountAddress. sendIcaMsg does
+// not read it: it finds the channel through the controller keeper's active channel instead.
+func (k Keeper) SetInterchainAccountAddress(ctx sdk.Context, contractAddr sdk.AccAddress, connectionID, icaAddress string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.InterchainAccountKey(contractAddr, connectionID), []byte(icaAddress))
+}
+
+// GetInterchainAccountAddress returns the hosted account address for (contractAddr,
+// connectionID), or "" if no account has been registered there yet.
+func (k Keeper) GetInterchainAccountAddress(ctx sdk.Context, contractAddr sdk.AccAddress, connectionID string) string {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.InterchainAccountKey(contractAddr, connectionID))
+	return string(bz)
+}
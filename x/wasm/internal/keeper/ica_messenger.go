@@ -0,0 +1,56 @@
+package keeper
+
+import (
+	"github.com/CosmWasm/wasmd/x/wasm/internal/types"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	icatypes "github.com/cosmos/ibc-go/modules/apps/27-interchain-accounts/types"
+	host "github.com/cosmos/ibc-go/modules/core/24-host"
+)
+
+// DispatchIBCMsg is the hook point for the contract messenger's CosmosMsg dispatch switch to call
+// for the CosmosMsg::Ica and CosmosMsg::Ibc(IbcMsg::WriteAcknowledgement) variants - that switch
+// lives in the messenger's own file, outside this package's scope, and isn't touched here; wiring
+// a case there to call DispatchIBCMsg is what makes these reachable from a contract, and hasn't
+// been done yet. Exactly one of msg.Ica/msg.WriteAcknowledgement is expected to be set, mirroring
+// how wasmvm decodes the other CosmosMsg enum variants.
+func (k Keeper) DispatchIBCMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractInfo types.ContractInfo, msg wasmvmtypes.IBCMsg) error {
+	switch {
+	case msg.Ica != nil:
+		return k.sendIcaMsg(ctx, contractAddr, *msg.Ica)
+	case msg.WriteAcknowledgement != nil:
+		return k.handleWriteAcknowledgementMsg(ctx, contractAddr, contractInfo, *msg.WriteAcknowledgement)
+	default:
+		return sdkerrors.Wrap(types.ErrInvalid, "unsupported IBC message variant")
+	}
+}
+
+// sendIcaMsg handles the CosmosMsg::Ica{connection_id, msgs, timeout, memo} variant: it packs
+// msgs as an ICS-27 InterchainAccountPacketData and sends it over the channel previously opened
+// by RegisterInterchainAccount for (contractAddr, msg.ConnectionID). The acknowledgement or
+// timeout of that packet is handled by the ICA controller module that bound the port, not by this
+// keeper's OnAckPacket/OnTimeoutPacket, so the contract is not called back with the result.
+//
+// There is no CosmosMsg::IcaQuery counterpart: ad-hoc interchain queries are a separate IBC
+// application unrelated to ICS-27, and the controller keeper has no SendQuery method to route
+// such a message through.
+func (k Keeper) sendIcaMsg(ctx sdk.Context, contractAddr sdk.AccAddress, msg wasmvmtypes.IcaMsg) error {
+	portID := types.ICAControllerPortID(contractAddr)
+	channelID, found := k.icaControllerKeeper.GetActiveChannelID(ctx, msg.ConnectionID, portID)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrInvalid, "no active ICA channel for contract %s on connection %s", contractAddr, msg.ConnectionID)
+	}
+	chanCap, found := k.scopedIBCKeeper.GetCapability(ctx, host.ChannelCapabilityPath(portID, channelID))
+	if !found {
+		return sdkerrors.Wrap(types.ErrInvalid, "channel capability not found")
+	}
+
+	packetData := icatypes.InterchainAccountPacketData{
+		Type: icatypes.EXECUTE_TX,
+		Data: msg.Msgs,
+		Memo: msg.Memo,
+	}
+	_, err := k.icaControllerKeeper.SendTx(ctx, chanCap, msg.ConnectionID, portID, packetData, msg.Timeout)
+	return err
+}
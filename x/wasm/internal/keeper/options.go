@@ -0,0 +1,52 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	icatypes "github.com/cosmos/ibc-go/modules/apps/27-interchain-accounts/types"
+)
+
+// Option is an extension point to instantiate the keeper with non-default values.
+type Option interface {
+	apply(*Keeper)
+}
+
+type optsFn func(*Keeper)
+
+func (f optsFn) apply(k *Keeper) {
+	f(k)
+}
+
+// WithIBCAckEnvelopeDisabled turns off automatic ICS-04 acknowledgement envelope decoding in
+// OnAckPacket, restoring the previous behavior of always calling the generic IBCPacketAck wasmvm
+// entry point regardless of the acknowledgement's shape. Chains whose contracts depend on that
+// single-callback behavior can pass this to NewKeeper to opt out.
+func WithIBCAckEnvelopeDisabled() Option {
+	return optsFn(func(k *Keeper) {
+		k.disableIBCAckEnvelope = true
+	})
+}
+
+// ICAControllerKeeper defines the subset of ibc-go's 27-interchain-accounts controller keeper that
+// RegisterInterchainAccount and the sendIcaMsg message handler need. This matches the real
+// controller keeper's own method signatures - in particular SendTx takes the channel capability
+// (not a channelID; the active channel is derived internally from connectionID+portID) and there
+// is no SendQuery: ad-hoc interchain queries are an unrelated IBC application, not part of ICS-27,
+// so CosmosMsg::IcaQuery has no controller keeper call to map to and isn't supported.
+type ICAControllerKeeper interface {
+	IsBound(ctx sdk.Context, portID string) bool
+	BindPort(ctx sdk.Context, portID string) error
+	RegisterInterchainAccount(ctx sdk.Context, connectionID, portID, version string) error
+	GetActiveChannelID(ctx sdk.Context, connectionID, portID string) (string, bool)
+	SendTx(ctx sdk.Context, chanCap *capabilitytypes.Capability, connectionID, portID string, icaPacketData icatypes.InterchainAccountPacketData, timeoutTimestamp uint64) (uint64, error)
+}
+
+// WithICAControllerKeeper wires in the ibc-go ICA controller keeper that RegisterInterchainAccount
+// and the CosmosMsg::Ica handler in ica_messenger.go dispatch through. Chains that don't register
+// the 27-interchain-accounts controller module can leave this unset; any contract call that needs
+// it will panic on the nil keeper, the same way an unset k.wasmer or k.messenger would.
+func WithICAControllerKeeper(k ICAControllerKeeper) Option {
+	return optsFn(func(keeper *Keeper) {
+		keeper.icaControllerKeeper = k
+	})
+}
@@ -1,17 +1,74 @@
 package keeper
 
 import (
+	"encoding/json"
+
 	"github.com/CosmWasm/wasmd/x/wasm/internal/types"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
+// stdAckEnvelope is the standard acknowledgement envelope recommended by
+// https://github.com/cosmos/ics/tree/master/spec/ics-004-channel-and-packet-semantics#acknowledgement-envelope
+// Exactly one of Result/Error is expected to be set.
+type stdAckEnvelope struct {
+	Result []byte `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// parseStdAckEnvelope tries to unmarshal the given acknowledgement bytes as the standard envelope.
+// It returns ok=false when the bytes don't match the envelope shape - which requires the object to
+// have exactly one of "result"/"error" and nothing else - so callers can fall back to passing the
+// raw acknowledgement through unchanged rather than misrouting an unrelated custom ack protocol
+// that happens to reuse one of those field names. A present-but-empty result (`{"result":""}` or
+// `{"result":null}`) is still a valid envelope success ack, so the field check is on key presence,
+// not on the decoded value being non-empty.
+func parseStdAckEnvelope(ack []byte) (stdAckEnvelope, bool) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(ack, &raw); err != nil {
+		return stdAckEnvelope{}, false
+	}
+	resultRaw, hasResult := raw["result"]
+	errorRaw, hasError := raw["error"]
+	switch {
+	case len(raw) == 1 && hasResult:
+		var env stdAckEnvelope
+		if err := json.Unmarshal(resultRaw, &env.Result); err != nil {
+			return stdAckEnvelope{}, false
+		}
+		return env, true
+	case len(raw) == 1 && hasError:
+		var env stdAckEnvelope
+		if err := json.Unmarshal(errorRaw, &env.Error); err != nil {
+			return stdAckEnvelope{}, false
+		}
+		return env, true
+	default:
+		return stdAckEnvelope{}, false
+	}
+}
+
 // OnOpenChannel calls the contract to participate in the IBC channel handshake step.
 // In the IBC protocol this is either the `Channel Open Init` event on the initiating chain or
 // `Channel Open Try` on the counterparty chain.
-// Protocol version and channel ordering should be verified for example.
 // See https://github.com/cosmos/ics/tree/master/spec/ics-004-channel-and-packet-semantics#channel-lifecycle-management
+//
+// The contract is expected to enforce its own version and ordering constraints here, on both the
+// init and try side, rather than waiting for OnConnectChannel - by the time that callback fires
+// the version is already final.
+//
+// wasmer's IBCChannelOpen response can itself propose a counter version, mirroring ibc-go's own
+// Ibc3ChannelOpenResponse, but there's nowhere for that to go yet: nothing in this series populates
+// channel.CounterpartyEndpoint before calling in, or reads a returned version back out to feed into
+// OnChanOpenInit/OnChanOpenTry's response - that's the IBCHandler's job and hasn't been wired up.
+// Until it is, this only validates the proposed version/ordering and reports success or failure,
+// the same as before.
+//
+// Channels on an ICA controller port (see RegisterInterchainAccount) never reach this method in
+// the first place: ibc-go's port router dispatches a channel to whichever IBCModule bound the
+// port, and RegisterInterchainAccount binds icacontroller-* ports to the ICA controller module's
+// own IBCModule, not to this one. There is no special-casing for that here because none is needed.
 func (k Keeper) OnOpenChannel(
 	ctx sdk.Context,
 	contractAddr sdk.AccAddress,
@@ -29,12 +86,11 @@ func (k Keeper) OnOpenChannel(
 	}
 
 	gas := gasForContract(ctx)
-	gasUsed, execErr := k.wasmer.IBCChannelOpen(codeInfo.CodeHash, env, channel, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	_, gasUsed, execErr := k.wasmer.IBCChannelOpen(codeInfo.CodeHash, env, channel, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
 	consumeGas(ctx, gasUsed)
 	if execErr != nil {
 		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
-
 	return nil
 }
 
@@ -45,6 +101,13 @@ func (k Keeper) OnOpenChannel(
 // There is an open issue with the [cosmos-sdk](https://github.com/cosmos/cosmos-sdk/issues/8334)
 // that the counterparty channelID is empty on the initiating chain
 // See https://github.com/cosmos/ics/tree/master/spec/ics-004-channel-and-packet-semantics#channel-lifecycle-management
+//
+// As in OnOpenChannel, channels on an ICA controller port never reach this method - ibc-go's port
+// router sends them to the ICA controller module's own IBCModule instead. SetInterchainAccountAddress
+// still needs to be called from somewhere once the counterparty's ICS-27 channel version metadata
+// is available (that's the earliest point the hosted account address is known), but that hook point
+// is the ICA controller module's own OnChanOpenConfirm/OnChanOpenAck callback in app.go, which isn't
+// part of this series - so SetInterchainAccountAddress is currently unreachable.
 func (k Keeper) OnConnectChannel(
 	ctx sdk.Context,
 	contractAddr sdk.AccAddress,
@@ -122,6 +185,22 @@ func (k Keeper) OnCloseChannel(
 // of IBC. Although it is recommended to use the standard acknowledgement envelope defined in
 // https://github.com/cosmos/ics/tree/master/spec/ics-004-channel-and-packet-semantics#acknowledgement-envelope
 //
+// wasmvm calls are all-or-nothing, so there is no partial contract event stream to recover when
+// the call fails - res is nil and the contract's state changes are reverted by the IBC handler.
+// The ack sent to the counterparty is redacted per ICS-04 in that case, so a packet_recv event
+// carrying the raw error and contract address is emitted instead, letting off-chain consumers
+// observe why the packet was rejected even though the ack itself doesn't say.
+//
+// If the response sets Async, the contract isn't ready to acknowledge the packet yet - for
+// example it still needs to query another chain or wait on a human/multisig. In that case no ack
+// is written here; instead (portID, channelID, sequence) is recorded against contractAddr via
+// SetAsyncAckOwner and a nil acknowledgement is returned so the caller writes none either.
+// Keeper.WriteAcknowledgement writes the real ack later, authorized against this record so only
+// the owning contract can write it. Neither MsgWriteAcknowledgement nor the contract messenger
+// routes to it (see handleWriteAcknowledgementMsg), so no transaction or contract message reaches
+// it from outside this package. This mirrors the async handling
+// outlined in ADR-015's packet receiver design.
+//
 // For more information see: https://github.com/cosmos/ics/tree/master/spec/ics-004-channel-and-packet-semantics#packet-flow--handling
 func (k Keeper) OnRecvPacket(
 	ctx sdk.Context,
@@ -143,6 +222,7 @@ func (k Keeper) OnRecvPacket(
 	res, gasUsed, execErr := k.wasmer.IBCPacketReceive(codeInfo.CodeHash, env, packet, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
 	consumeGas(ctx, gasUsed)
 	if execErr != nil {
+		k.emitIBCFailureEvent(ctx, types.EventTypePacketRecv, contractAddr, execErr)
 		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
@@ -153,13 +233,41 @@ func (k Keeper) OnRecvPacket(
 	if err := k.messenger.Dispatch(ctx, contractAddr, contractInfo.IBCPortID, res.Messages...); err != nil {
 		return nil, err
 	}
+
+	if res.Async {
+		k.SetAsyncAckOwner(ctx, contractAddr, packet)
+		return nil, nil
+	}
 	return res.Acknowledgement, nil
 }
 
+// emitIBCFailureEvent emits an eventType event exposing the raw error and contract address for a
+// failed IBC packet lifecycle callback, so consumers observing the parent event manager can learn
+// what went wrong even though the ack written to the counterparty (if any) redacts it. wasmvm
+// calls are all-or-nothing, so there is no partial contract event stream to also re-emit here.
+func (k Keeper) emitIBCFailureEvent(ctx sdk.Context, eventType string, contractAddr sdk.AccAddress, execErr error) {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		eventType,
+		sdk.NewAttribute(types.AttributeKeyAckSuccess, "false"),
+		sdk.NewAttribute(types.AttributeKeyAckError, execErr.Error()),
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+	))
+}
+
 // OnAckPacket calls the contract to handle the "acknowledgement" data which can contain success or failure of a packet
 // acknowledgement written on the receiving chain for example. This is application level data and fully owned by the
 // contract. The use of the standard acknowledgement envelope is recommended: https://github.com/cosmos/ics/tree/master/spec/ics-004-channel-and-packet-semantics#acknowledgement-envelope
 //
+// When the acknowledgement bytes parse as the standard envelope, the contract is called through the dedicated
+// IBCPacketAckSuccess/IBCPacketAckError wasmvm entry points instead of the generic IBCPacketAck, so contracts no
+// longer have to branch on the envelope themselves. Acknowledgements that don't fit the envelope (e.g. custom
+// protocols) fall back to the generic entry point unchanged. Chains that rely on the previous, single-callback
+// behavior can pass WithIBCAckEnvelopeDisabled to NewKeeper to keep it.
+//
+// Acknowledgements for packets sent over an ICA controller channel (see sendIcaMsg) do not reach
+// this method: as with OnOpenChannel, ibc-go routes them to the ICA controller module that bound
+// the port, so the sending contract is not called back with the result.
+//
 // On application errors the contract can revert an operation like returning tokens as in ibc-transfer.
 //
 // For more information see: https://github.com/cosmos/ics/tree/master/spec/ics-004-channel-and-packet-semantics#packet-flow--handling
@@ -180,9 +288,21 @@ func (k Keeper) OnAckPacket(
 	}
 
 	gas := gasForContract(ctx)
-	res, gasUsed, execErr := k.wasmer.IBCPacketAck(codeInfo.CodeHash, env, acknowledgement, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	var res *wasmvmtypes.IBCBasicResponse
+	var gasUsed uint64
+	var execErr error
+	if ack, ok := parseStdAckEnvelope(acknowledgement.Acknowledgement); ok && !k.disableIBCAckEnvelope {
+		if ack.Error != "" {
+			res, gasUsed, execErr = k.wasmer.IBCPacketAckError(codeInfo.CodeHash, env, acknowledgement.OriginalPacket, ack.Error, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
+		} else {
+			res, gasUsed, execErr = k.wasmer.IBCPacketAckSuccess(codeInfo.CodeHash, env, acknowledgement.OriginalPacket, ack.Result, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
+		}
+	} else {
+		res, gasUsed, execErr = k.wasmer.IBCPacketAck(codeInfo.CodeHash, env, acknowledgement, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
+	}
 	consumeGas(ctx, gasUsed)
 	if execErr != nil {
+		k.emitIBCFailureEvent(ctx, types.EventTypePacketAck, contractAddr, execErr)
 		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
@@ -219,6 +339,7 @@ func (k Keeper) OnTimeoutPacket(
 	res, gasUsed, execErr := k.wasmer.IBCPacketTimeout(codeInfo.CodeHash, env, packet, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gas)
 	consumeGas(ctx, gasUsed)
 	if execErr != nil {
+		k.emitIBCFailureEvent(ctx, types.EventTypePacketTimeout, contractAddr, execErr)
 		return sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
 
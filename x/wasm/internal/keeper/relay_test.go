@@ -0,0 +1,59 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStdAckEnvelope(t *testing.T) {
+	specs := map[string]struct {
+		ack      string
+		expOk    bool
+		expError string
+	}{
+		"success envelope": {
+			ack:   `{"result":"aGVsbG8="}`,
+			expOk: true,
+		},
+		"empty string result is still the envelope": {
+			ack:   `{"result":""}`,
+			expOk: true,
+		},
+		"null result is still the envelope": {
+			ack:   `{"result":null}`,
+			expOk: true,
+		},
+		"error envelope": {
+			ack:      `{"error":"boom"}`,
+			expOk:    true,
+			expError: "boom",
+		},
+		"both result and error is not the envelope": {
+			ack:   `{"result":"aGVsbG8=","error":"boom"}`,
+			expOk: false,
+		},
+		"unrelated field alongside result is not the envelope": {
+			ack:   `{"result":"aGVsbG8=","custom_protocol_field":1}`,
+			expOk: false,
+		},
+		"custom ack that happens to use a different field name is not the envelope": {
+			ack:   `{"status":"ok"}`,
+			expOk: false,
+		},
+		"non-object ack is not the envelope": {
+			ack:   `"raw string ack"`,
+			expOk: false,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			env, ok := parseStdAckEnvelope([]byte(spec.ack))
+			require.Equal(t, spec.expOk, ok)
+			if spec.expOk {
+				assert.Equal(t, spec.expError, env.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+)
+
+// AsyncAckOwnerPrefix is the KV store prefix under which (portID, channelID, sequence) ->
+// AsyncAckEntry entries live for packets whose IBCPacketReceive response deferred the
+// acknowledgement (Async: true) instead of returning it synchronously from OnRecvPacket.
+var AsyncAckOwnerPrefix = []byte{0x09}
+
+// AsyncAckOwnerKey returns the store key for the async ack owner of (portID, channelID,
+// sequence).
+func AsyncAckOwnerKey(portID, channelID string, sequence uint64) []byte {
+	key := make([]byte, 0, len(AsyncAckOwnerPrefix)+len(portID)+len(channelID)+10)
+	key = append(key, AsyncAckOwnerPrefix...)
+	key = append(key, []byte(portID)...)
+	key = append(key, '/')
+	key = append(key, []byte(channelID)...)
+	key = append(key, '/')
+	key = append(key, sdk.Uint64ToBigEndian(sequence)...)
+	return key
+}
+
+// AsyncAckEntry is the value stored under AsyncAckOwnerKey. The packet is cached here at
+// OnRecvPacket time because core IBC's ChannelKeeper only persists a receipt marker once a packet
+// is delivered (to protect against replay) - it never keeps the packet itself around for a later
+// WriteAcknowledgement call, so the keeper has to hold onto it for the contract instead.
+type AsyncAckEntry struct {
+	ContractAddr sdk.AccAddress      `json:"contract_addr"`
+	Packet       channeltypes.Packet `json:"packet"`
+}
+
+// MarshalAsyncAckEntry JSON-encodes an AsyncAckEntry for storage.
+func MarshalAsyncAckEntry(entry AsyncAckEntry) ([]byte, error) {
+	return json.Marshal(entry)
+}
+
+// UnmarshalAsyncAckEntry decodes a value previously produced by MarshalAsyncAckEntry.
+func UnmarshalAsyncAckEntry(bz []byte) (AsyncAckEntry, error) {
+	var entry AsyncAckEntry
+	err := json.Unmarshal(bz, &entry)
+	return entry, err
+}
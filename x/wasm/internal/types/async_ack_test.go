@@ -0,0 +1,46 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncAckOwnerKeyIsUniquePerPacket(t *testing.T) {
+	keyA := AsyncAckOwnerKey("wasm.contractA", "channel-0", 1)
+	keyB := AsyncAckOwnerKey("wasm.contractA", "channel-0", 2)
+	keyC := AsyncAckOwnerKey("wasm.contractA", "channel-1", 1)
+	keyD := AsyncAckOwnerKey("wasm.contractB", "channel-0", 1)
+
+	assert.NotEqual(t, keyA, keyB, "different sequence must produce a different key")
+	assert.NotEqual(t, keyA, keyC, "different channel must produce a different key")
+	assert.NotEqual(t, keyA, keyD, "different port must produce a different key")
+	assert.Equal(t, keyA, AsyncAckOwnerKey("wasm.contractA", "channel-0", 1), "same inputs must produce the same key")
+}
+
+func TestAsyncAckEntryRoundTrip(t *testing.T) {
+	contractAddr := sdk.AccAddress([]byte("contract-address----"))
+	entry := AsyncAckEntry{
+		ContractAddr: contractAddr,
+		Packet: channeltypes.Packet{
+			Sequence:           1,
+			SourcePort:         "wasm.contractA",
+			SourceChannel:      "channel-0",
+			DestinationPort:    "transfer",
+			DestinationChannel: "channel-1",
+			Data:               []byte("packet-data"),
+			TimeoutTimestamp:   12345,
+		},
+	}
+
+	bz, err := MarshalAsyncAckEntry(entry)
+	require.NoError(t, err)
+
+	got, err := UnmarshalAsyncAckEntry(bz)
+	require.NoError(t, err)
+	assert.Equal(t, entry.ContractAddr, got.ContractAddr)
+	assert.Equal(t, entry.Packet, got.Packet)
+}
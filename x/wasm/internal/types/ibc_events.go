@@ -0,0 +1,17 @@
+package types
+
+// Event types and attribute keys emitted by the IBC packet lifecycle keeper methods
+// (OnRecvPacket, OnAckPacket, OnTimeoutPacket) when the wasmvm call fails. wasmvm calls are
+// all-or-nothing - there is no partial contract-emitted event stream to recover on failure - so
+// these are the only events the keeper itself emits in that case, carrying just enough routing
+// and error information for off-chain consumers to observe why the packet was rejected even
+// though the ack (if any) sent to the counterparty redacts it per ICS-04.
+const (
+	EventTypePacketRecv    = "packet_recv"
+	EventTypePacketAck     = "packet_ack"
+	EventTypePacketTimeout = "packet_timeout"
+
+	AttributeKeyAckSuccess   = "ack_success"
+	AttributeKeyAckError     = "ack_error"
+	AttributeKeyContractAddr = "contract_address"
+)
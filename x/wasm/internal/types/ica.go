@@ -0,0 +1,51 @@
+package types
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ICAControllerPortPrefix is prepended to the contract address when deriving the port ID an
+// Interchain Accounts (ICS-27) controller channel is bound to, mirroring the `icacontroller-`
+// convention used by ibc-go's own ICA controller module.
+const ICAControllerPortPrefix = "icacontroller-"
+
+// ICAControllerPortID returns the port ID a contract's interchain account controller binds to.
+func ICAControllerPortID(contractAddr sdk.AccAddress) string {
+	return ICAControllerPortPrefix + contractAddr.String()
+}
+
+// IsICAControllerPort reports whether portID was derived by ICAControllerPortID, i.e. the channel
+// it belongs to is a handshake-only ICS-27 controller channel rather than a regular contract IBC
+// channel routed through the wasmer callbacks.
+func IsICAControllerPort(portID string) bool {
+	return strings.HasPrefix(portID, ICAControllerPortPrefix)
+}
+
+// ICAChannelVersionMetadata mirrors the JSON the ICA host chain encodes into the channel version
+// once the channel is established, as defined by ICS-27. Address is populated with the hosted
+// account address and is only meaningful once the handshake has completed.
+type ICAChannelVersionMetadata struct {
+	Version                string `json:"version"`
+	ControllerConnectionID string `json:"controller_connection_id"`
+	HostConnectionID       string `json:"host_connection_id"`
+	Address                string `json:"address"`
+	Encoding               string `json:"encoding"`
+	TxType                 string `json:"tx_type"`
+}
+
+// InterchainAccountPrefix is the KV store prefix under which (contractAddr, connectionID) ->
+// icaAddress mappings are stored.
+var InterchainAccountPrefix = []byte{0x08}
+
+// InterchainAccountKey returns the store key for the interchain account a contract registered on
+// connectionID.
+func InterchainAccountKey(contractAddr sdk.AccAddress, connectionID string) []byte {
+	key := make([]byte, 0, len(InterchainAccountPrefix)+len(contractAddr)+1+len(connectionID))
+	key = append(key, InterchainAccountPrefix...)
+	key = append(key, contractAddr...)
+	key = append(key, '/')
+	key = append(key, []byte(connectionID)...)
+	return key
+}
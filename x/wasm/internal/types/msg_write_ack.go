@@ -0,0 +1,50 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MsgWriteAcknowledgement lets the contract that deferred an acknowledgement from OnRecvPacket
+// (by setting Async: true on its IBCPacketReceive response) write it once it's ready, instead of
+// returning it synchronously. This is needed for contracts that must query another chain or wait
+// on a human/multisig before acking, mirroring the async handling outlined in ADR-015's packet
+// receiver design.
+//
+// This type is not yet reachable by any transaction: it isn't registered with the amino/proto
+// codec, routed by a NewHandler switch, or registered with a msg-service router anywhere in this
+// series. Keeper.WriteAcknowledgement, which this is meant to authorize and call, can currently
+// only be reached from a contract via Keeper.handleWriteAcknowledgementMsg - and that path is
+// itself unwired (see its doc comment in keeper/async_ack.go).
+type MsgWriteAcknowledgement struct {
+	Sender          sdk.AccAddress `json:"sender" yaml:"sender"`
+	PortID          string         `json:"port_id" yaml:"port_id"`
+	ChannelID       string         `json:"channel_id" yaml:"channel_id"`
+	Sequence        uint64         `json:"sequence" yaml:"sequence"`
+	Acknowledgement []byte         `json:"acknowledgement" yaml:"acknowledgement"`
+}
+
+func (msg MsgWriteAcknowledgement) Route() string { return RouterKey }
+
+func (msg MsgWriteAcknowledgement) Type() string { return "write_acknowledgement" }
+
+func (msg MsgWriteAcknowledgement) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender")
+	}
+	if msg.PortID == "" || msg.ChannelID == "" {
+		return sdkerrors.Wrap(ErrInvalid, "port id and channel id are required")
+	}
+	if len(msg.Acknowledgement) == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "acknowledgement is required")
+	}
+	return nil
+}
+
+func (msg MsgWriteAcknowledgement) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgWriteAcknowledgement) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}